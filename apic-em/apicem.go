@@ -2,6 +2,7 @@ package apicem
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -12,6 +13,7 @@ import (
 	"strings"
 
 	"github.com/google/go-querystring/query"
+	"go.opentelemetry.io/otel/trace"
 )
 
 const (
@@ -39,6 +41,21 @@ type Client struct {
 	// Authorization is the authentication token
 	Authorization string
 
+	// Authenticator, if set, supplies and refreshes the X-Auth-Token used on every
+	// request, overriding Authorization. See BasicAuthAuthenticator.
+	Authenticator Authenticator
+
+	// RetryPolicy controls how Do retries a failed request. Defaults to DefaultRetryPolicy;
+	// set to a zero-value &RetryPolicy{} (or leave MaxRetries at 0) to disable retries.
+	RetryPolicy *RetryPolicy
+
+	// logger receives structured events for every request. Defaults to a no-op logger; set
+	// via the SetLogger ClientOpt.
+	logger Logger
+
+	// tracer, if set via WithTracer, opens an OpenTelemetry span around every request.
+	tracer trace.Tracer
+
 	common service // Reuse a single struct instead of allocating one for each service on the heap
 
 	// Services used for communicating with the APIC-EM API
@@ -109,18 +126,10 @@ type Response struct {
 
 	// Monitoring URI
 	Monitor string
-}
-
-// An ErrorResponse reports the error caused by an API request
-type ErrorResponse struct {
-	// HTTP response that caused this error
-	HTTPResponse *http.Response
 
-	Message string
-	Errors  []struct {
-		Description string
-	}
-	TrackingID string
+	// Rate contains the rate limit information reported by the controller on this
+	// response, if any.
+	Rate Rate
 }
 
 func addOptions(s string, opt interface{}) (string, error) {
@@ -158,7 +167,7 @@ func NewClient(httpClient *http.Client) *Client {
 
 	baseURL, _ := url.Parse(defaultBaseURL)
 
-	c := &Client{client: httpClient, BaseURL: baseURL, UserAgent: userAgent, Authorization: authorizationToken}
+	c := &Client{client: httpClient, BaseURL: baseURL, UserAgent: userAgent, Authorization: authorizationToken, RetryPolicy: DefaultRetryPolicy(), logger: noopLogger{}}
 	c.common.client = c
 	c.AAA = (*AAAService)(&c.common)
 	c.Alarm = (*AlarmService)(&c.common)
@@ -241,7 +250,15 @@ func SetUserAgent(ua string) ClientOpt {
 // NewRequest creates an API request. A relative URL can be provided in urlStr, which will be resolved to the
 // BaseURL of the Client. Relative URLS should always be specified without a preceding slash. If specified, the
 // value pointed to by body is JSON encoded and included in as the request body.
+//
+// NewRequest is a convenience wrapper around NewRequestWithContext using context.Background.
 func (c *Client) NewRequest(method, urlStr string, body interface{}) (*http.Request, error) {
+	return c.NewRequestWithContext(context.Background(), method, urlStr, body)
+}
+
+// NewRequestWithContext is like NewRequest but attaches ctx to the returned request, so that it can be
+// cancelled or given a deadline by the caller, or by a CallOption passed to DoWithContext.
+func (c *Client) NewRequestWithContext(ctx context.Context, method, urlStr string, body interface{}) (*http.Request, error) {
 	method = strings.ToUpper(method)
 	rel, err := url.Parse(urlStr)
 	if err != nil {
@@ -250,19 +267,26 @@ func (c *Client) NewRequest(method, urlStr string, body interface{}) (*http.Requ
 
 	u := c.BaseURL.ResolveReference(rel)
 
-	buf := new(bytes.Buffer)
+	var bodyBytes []byte
 	if body != nil {
-		err := json.NewEncoder(buf).Encode(body)
-		if err != nil {
+		buf := new(bytes.Buffer)
+		if err := json.NewEncoder(buf).Encode(body); err != nil {
 			return nil, err
 		}
+		bodyBytes = buf.Bytes()
 	}
 
-	req, err := http.NewRequest(method, u.String(), buf)
+	req, err := http.NewRequestWithContext(ctx, method, u.String(), bytes.NewReader(bodyBytes))
 	if err != nil {
 		return nil, err
 	}
 
+	// Captured explicitly (rather than relying on http.NewRequest's bytes.Reader detection) so the
+	// retry loop in Do can always rewind the body, regardless of how the request was constructed.
+	req.GetBody = func() (io.ReadCloser, error) {
+		return io.NopCloser(bytes.NewReader(bodyBytes)), nil
+	}
+
 	req.Header.Add("Content-Type", mediaType)
 	req.Header.Add("Accept", mediaType)
 	req.Header.Add("User-Agent", c.UserAgent)
@@ -284,10 +308,44 @@ func newResponse(r *http.Response) *Response {
 	return &response
 }
 
-// Do sends an API request and returns the API response. The API response is JSON decoded and stored in the value
-// pointed to by v, or returned as an error if an API error has occurred. If v implements the io.Writer interface,
-// the raw response will be written to v, without attempting to decode it.
-func (c *Client) Do(req *http.Request, v interface{}) (*Response, error) {
+// authenticatedDo sends a single attempt of req, injecting a token from c.Authenticator if one is set. If the
+// controller rejects the request as unauthorized, it invalidates the cached token, fetches a new one, and
+// retries the request once before giving up. Unlike Do, it does not apply c.RetryPolicy.
+func (c *Client) authenticatedDo(req *http.Request, v interface{}) (*Response, error) {
+	ctx := req.Context()
+
+	if c.Authenticator != nil {
+		token, _, err := c.Authenticator.Token(ctx)
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("X-Auth-Token", token)
+	}
+
+	response, err := c.rawDo(req, v)
+	if c.Authenticator == nil || !IsUnauthorized(err) {
+		return response, err
+	}
+
+	c.Authenticator.Invalidate()
+	token, _, terr := c.Authenticator.Token(ctx)
+	if terr != nil {
+		return response, err
+	}
+	req.Header.Set("X-Auth-Token", token)
+	if req.GetBody != nil {
+		body, berr := req.GetBody()
+		if berr != nil {
+			return response, err
+		}
+		req.Body = body
+	}
+
+	return c.rawDo(req, v)
+}
+
+// rawDo performs a single request/response round trip, with no authentication or retry handling.
+func (c *Client) rawDo(req *http.Request, v interface{}) (response *Response, err error) {
 	resp, err := c.client.Do(req)
 	if err != nil {
 		return nil, err
@@ -296,19 +354,17 @@ func (c *Client) Do(req *http.Request, v interface{}) (*Response, error) {
 		c.onRequestCompleted(req, resp)
 	}
 
+	// response and err are named returns so this defer's Close error actually reaches the
+	// caller instead of only mutating a local already copied into the return slot.
 	defer func() {
-		if rerr := resp.Body.Close(); err == nil {
-			err = rerr
-		}
-	}()
-
-	defer func() {
-		// Drain up to 512 bytes and close the body to let the Transport reuse the connection
+		// Drain up to 512 bytes first so the Transport can reuse the connection.
 		io.CopyN(ioutil.Discard, resp.Body, 512)
-		resp.Body.Close()
+		if cerr := resp.Body.Close(); err == nil {
+			err = cerr
+		}
 	}()
 
-	response := newResponse(resp)
+	response = newResponse(resp)
 
 	err = CheckResponse(resp)
 	if err != nil {
@@ -317,13 +373,11 @@ func (c *Client) Do(req *http.Request, v interface{}) (*Response, error) {
 
 	if v != nil {
 		if w, ok := v.(io.Writer); ok {
-			_, err := io.Copy(w, resp.Body)
-			if err != nil {
+			if _, err = io.Copy(w, resp.Body); err != nil {
 				return nil, err
 			}
 		} else {
-			err := json.NewDecoder(resp.Body).Decode(v)
-			if err != nil {
+			if err = json.NewDecoder(resp.Body).Decode(v); err != nil {
 				return nil, err
 			}
 		}
@@ -332,35 +386,6 @@ func (c *Client) Do(req *http.Request, v interface{}) (*Response, error) {
 	return response, err
 }
 
-func (r *ErrorResponse) Error() string {
-	return fmt.Sprintf("%v %v: %d %v",
-		r.HTTPResponse.Request.Method, r.HTTPResponse.Request.URL, r.HTTPResponse.StatusCode, r.Message)
-}
-
-// CheckResponse checks the API response for errors, and returns them if present. A response is considered an
-// error if it has a status code outside the 200 range. API error responses are expected to have either no response
-// body, or a JSON response body that maps to ErrorResponse. Any other response body will be silently ignored.
-func CheckResponse(r *http.Response) error {
-	if c := r.StatusCode; c >= 200 && c <= 299 {
-		return nil
-	}
-	errorResponse := &ErrorResponse{HTTPResponse: r}
-	fmt.Println("ERROR", errorResponse)
-
-	data, err := ioutil.ReadAll(r.Body)
-	if err == nil && data != nil {
-		json.Unmarshal(data, errorResponse)
-	}
-	if err == nil && len(data) > 0 {
-		err := json.Unmarshal(data, errorResponse)
-		if err != nil {
-			return err
-		}
-	}
-
-	return errorResponse
-}
-
 // String is a helper routine that allocates a new string value
 // to store v and returns a pointer to it.
 func String(v string) *string {
@@ -0,0 +1,75 @@
+package apicem
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// tokenRefreshSkew is subtracted from the ticket's reported session timeout so that
+// Token() proactively refreshes slightly before the APIC-EM controller expires it.
+const tokenRefreshSkew = 30 * time.Second
+
+// Authenticator supplies the X-Auth-Token header used to authenticate requests to the
+// APIC-EM controller. Implementations are responsible for obtaining, caching, and
+// refreshing their own credentials. Token and Invalidate must be safe for concurrent use.
+type Authenticator interface {
+	// Token returns a valid auth token and the time at which it expires. Implementations
+	// should cache the token and only contact the controller when it is missing or expired.
+	Token(ctx context.Context) (token string, expiry time.Time, err error)
+
+	// Invalidate discards any cached token, forcing the next call to Token to fetch a
+	// fresh one. It is called when the controller rejects a request as unauthorized.
+	Invalidate()
+}
+
+// BasicAuthAuthenticator is an Authenticator that exchanges a username and password for
+// an APIC-EM service ticket, and transparently requests a new one once the cached ticket
+// expires or is invalidated.
+type BasicAuthAuthenticator struct {
+	client   *Client
+	username string
+	password string
+
+	mu     sync.Mutex
+	token  string
+	expiry time.Time
+}
+
+// NewBasicAuthAuthenticator returns an Authenticator that authenticates against client
+// using username and password.
+func NewBasicAuthAuthenticator(client *Client, username, password string) *BasicAuthAuthenticator {
+	return &BasicAuthAuthenticator{client: client, username: username, password: password}
+}
+
+// Token returns the cached service ticket, fetching a new one via TicketService.Create if
+// none is cached or the cached one has expired.
+func (a *BasicAuthAuthenticator) Token(ctx context.Context) (string, time.Time, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if a.token != "" && time.Now().Before(a.expiry) {
+		return a.token, a.expiry, nil
+	}
+
+	ticket, _, err := a.client.Ticket.Create(ctx, a.username, a.password)
+	if err != nil {
+		return "", time.Time{}, fmt.Errorf("apicem: fetching ticket: %w", err)
+	}
+	if ticket.ServiceTicket == "" {
+		return "", time.Time{}, fmt.Errorf("apicem: ticket response did not contain a serviceTicket")
+	}
+
+	a.token = ticket.ServiceTicket
+	a.expiry = time.Now().Add(time.Duration(ticket.SessionTimeout)*time.Second - tokenRefreshSkew)
+	return a.token, a.expiry, nil
+}
+
+// Invalidate discards the cached service ticket.
+func (a *BasicAuthAuthenticator) Invalidate() {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.token = ""
+	a.expiry = time.Time{}
+}
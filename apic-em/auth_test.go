@@ -0,0 +1,140 @@
+package apicem
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestBasicAuthAuthenticatorTokenCachesUntilExpiry(t *testing.T) {
+	var tickets int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		tickets++
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"response":{"serviceTicket":"tok-1","sessionTimeout":3600}}`))
+	}))
+	t.Cleanup(srv.Close)
+
+	c := NewClient(nil)
+	if err := SetBaseURL(srv.URL + "/")(c); err != nil {
+		t.Fatalf("SetBaseURL: %v", err)
+	}
+	a := NewBasicAuthAuthenticator(c, "user", "pass")
+
+	token1, _, err := a.Token(context.Background())
+	if err != nil {
+		t.Fatalf("Token: %v", err)
+	}
+	if token1 != "tok-1" {
+		t.Fatalf("token = %q, want tok-1", token1)
+	}
+
+	token2, _, err := a.Token(context.Background())
+	if err != nil {
+		t.Fatalf("Token: %v", err)
+	}
+	if token2 != token1 {
+		t.Fatalf("second Token() = %q, want the cached %q", token2, token1)
+	}
+	if tickets != 1 {
+		t.Fatalf("ticket endpoint hit %d times, want 1 (second call should use the cache)", tickets)
+	}
+}
+
+func TestBasicAuthAuthenticatorInvalidateForcesRefetch(t *testing.T) {
+	var tickets int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		tickets++
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"response":{"serviceTicket":"tok-1","sessionTimeout":3600}}`))
+	}))
+	t.Cleanup(srv.Close)
+
+	c := NewClient(nil)
+	if err := SetBaseURL(srv.URL + "/")(c); err != nil {
+		t.Fatalf("SetBaseURL: %v", err)
+	}
+	a := NewBasicAuthAuthenticator(c, "user", "pass")
+
+	if _, _, err := a.Token(context.Background()); err != nil {
+		t.Fatalf("Token: %v", err)
+	}
+	a.Invalidate()
+	if _, _, err := a.Token(context.Background()); err != nil {
+		t.Fatalf("Token: %v", err)
+	}
+	if tickets != 2 {
+		t.Fatalf("ticket endpoint hit %d times, want 2 (Invalidate should force a refetch)", tickets)
+	}
+}
+
+// stubAuthenticator returns the next entry of tokens each time Token is called, repeating the
+// last one once the list is used up (so the test can tell apart the first and second tokens),
+// with an expiry an hour out, and records Invalidate calls.
+type stubAuthenticator struct {
+	tokens      []string
+	next        int
+	invalidated int
+}
+
+func (a *stubAuthenticator) Token(ctx context.Context) (string, time.Time, error) {
+	token := a.tokens[a.next]
+	if a.next < len(a.tokens)-1 {
+		a.next++
+	}
+	return token, time.Now().Add(time.Hour), nil
+}
+
+func (a *stubAuthenticator) Invalidate() {
+	a.invalidated++
+}
+
+func TestAuthenticatedDoRetriesOnceAfterInvalidatingOn401(t *testing.T) {
+	var requests []string
+	var bodies []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Header.Get("X-Auth-Token"))
+		body := new(bytes.Buffer)
+		body.ReadFrom(r.Body)
+		bodies = append(bodies, body.String())
+
+		if len(requests) == 1 {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(srv.Close)
+
+	c := NewClient(nil)
+	if err := SetBaseURL(srv.URL + "/")(c); err != nil {
+		t.Fatalf("SetBaseURL: %v", err)
+	}
+	auth := &stubAuthenticator{tokens: []string{"stale-token", "fresh-token"}}
+	c.Authenticator = auth
+
+	req, err := c.NewRequest(http.MethodPost, "x", map[string]string{"k": "v"})
+	if err != nil {
+		t.Fatalf("NewRequest: %v", err)
+	}
+
+	if _, err := c.Do(req, nil); err != nil {
+		t.Fatalf("Do: %v", err)
+	}
+
+	if len(requests) != 2 {
+		t.Fatalf("controller saw %d requests, want exactly 2 (one retry after the 401)", len(requests))
+	}
+	if requests[0] != "stale-token" || requests[1] != "fresh-token" {
+		t.Fatalf("tokens used = %v, want [stale-token fresh-token]", requests)
+	}
+	if auth.invalidated != 1 {
+		t.Fatalf("Invalidate called %d times, want 1", auth.invalidated)
+	}
+	if bodies[0] != bodies[1] || bodies[1] == "" {
+		t.Fatalf("bodies = %q, want the retried request's body rewound to match the original", bodies)
+	}
+}
@@ -0,0 +1,84 @@
+package apicem
+
+import (
+	"context"
+	"net/http"
+	"time"
+)
+
+// callConfig accumulates the effect of the CallOptions passed to DoWithContext.
+type callConfig struct {
+	headers map[string]string
+	query   map[string]string
+	timeout time.Duration
+}
+
+// CallOption customizes a single call to DoWithContext, without affecting the Client's
+// defaults or any other in-flight call.
+type CallOption func(*callConfig) error
+
+// WithHeader sets an additional header on the outgoing request, overriding any header of
+// the same name set by NewRequest.
+func WithHeader(key, value string) CallOption {
+	return func(cfg *callConfig) error {
+		if cfg.headers == nil {
+			cfg.headers = make(map[string]string)
+		}
+		cfg.headers[key] = value
+		return nil
+	}
+}
+
+// WithQueryParam adds an additional query string parameter to the outgoing request's URL,
+// overriding any existing value for the same key.
+func WithQueryParam(key, value string) CallOption {
+	return func(cfg *callConfig) error {
+		if cfg.query == nil {
+			cfg.query = make(map[string]string)
+		}
+		cfg.query[key] = value
+		return nil
+	}
+}
+
+// WithTimeout bounds the call to d, on top of any deadline already carried by the context
+// passed to DoWithContext. The timeout is cancelled as soon as the call returns.
+func WithTimeout(d time.Duration) CallOption {
+	return func(cfg *callConfig) error {
+		cfg.timeout = d
+		return nil
+	}
+}
+
+// DoWithContext is like Do, but binds req to ctx before sending it and applies opts. ctx
+// governs cancellation of the request, including any automatic retry described in Do's
+// documentation.
+func (c *Client) DoWithContext(ctx context.Context, req *http.Request, v interface{}, opts ...CallOption) (*Response, error) {
+	cfg := &callConfig{}
+	for _, opt := range opts {
+		if err := opt(cfg); err != nil {
+			return nil, err
+		}
+	}
+
+	if cfg.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, cfg.timeout)
+		defer cancel()
+	}
+
+	req = req.WithContext(ctx)
+
+	for key, value := range cfg.headers {
+		req.Header.Set(key, value)
+	}
+	if len(cfg.query) > 0 {
+		q := req.URL.Query()
+		for key, value := range cfg.query {
+			q.Set(key, value)
+		}
+		req.URL.RawQuery = q.Encode()
+	}
+
+	return c.Do(req, v)
+}
@@ -0,0 +1,27 @@
+package apicem
+
+import "testing"
+
+func TestDiscoveryIDFromTaskErrorsOnEmptyData(t *testing.T) {
+	_, err := discoveryIDFromTask(&Task{ID: "abc", Data: ""})
+	if err == nil {
+		t.Fatal("expected an error when a completed task reports no data, not a silent zero id")
+	}
+}
+
+func TestDiscoveryIDFromTaskErrorsOnDataWithoutID(t *testing.T) {
+	_, err := discoveryIDFromTask(&Task{ID: "abc", Data: `{"status":"complete"}`})
+	if err == nil {
+		t.Fatal("expected an error when a completed task's data has no id")
+	}
+}
+
+func TestDiscoveryIDFromTaskReturnsID(t *testing.T) {
+	id, err := discoveryIDFromTask(&Task{ID: "abc", Data: `{"id":42}`})
+	if err != nil {
+		t.Fatalf("discoveryIDFromTask: %v", err)
+	}
+	if id != 42 {
+		t.Fatalf("id = %d, want 42", id)
+	}
+}
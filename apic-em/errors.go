@@ -0,0 +1,131 @@
+package apicem
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+)
+
+// Sentinel errors classifying the outcome of a request, so callers can check errors.Is
+// without depending on the concrete *ErrorResponse or *TaskError type.
+var (
+	ErrNotFound     = errors.New("apicem: not found")
+	ErrUnauthorized = errors.New("apicem: unauthorized")
+	ErrConflict     = errors.New("apicem: conflict")
+	ErrRateLimited  = errors.New("apicem: rate limited")
+	ErrTaskFailed   = errors.New("apicem: task failed")
+)
+
+// IsNotFound reports whether err indicates the requested resource does not exist (HTTP 404).
+func IsNotFound(err error) bool { return errors.Is(err, ErrNotFound) }
+
+// IsUnauthorized reports whether err indicates the request was rejected as unauthenticated
+// or carrying an expired/invalid token (HTTP 401).
+func IsUnauthorized(err error) bool { return errors.Is(err, ErrUnauthorized) }
+
+// IsConflict reports whether err indicates the request conflicted with the resource's
+// current state (HTTP 409).
+func IsConflict(err error) bool { return errors.Is(err, ErrConflict) }
+
+// IsRateLimited reports whether err indicates the controller throttled the request
+// (HTTP 429).
+func IsRateLimited(err error) bool { return errors.Is(err, ErrRateLimited) }
+
+// IsTaskFailed reports whether err indicates an asynchronous APIC-EM task finished in an
+// error state. If so, errors.As can recover the *TaskError for its ErrorCode and
+// FailureReason.
+func IsTaskFailed(err error) bool { return errors.Is(err, ErrTaskFailed) }
+
+// FieldError reports a validation error APIC-EM attributed to a specific request field.
+type FieldError struct {
+	Field       string `json:"field,omitempty"`
+	Description string `json:"description,omitempty"`
+}
+
+// ErrorResponse reports the error caused by an API request. It implements Unwrap so that
+// errors.Is(err, ErrNotFound) and friends work without a type assertion.
+type ErrorResponse struct {
+	// HTTPResponse is the HTTP response that caused this error.
+	HTTPResponse *http.Response `json:"-"`
+
+	// StatusCode is HTTPResponse.StatusCode, duplicated here for convenience.
+	StatusCode int `json:"-"`
+
+	// Code is the APIC-EM errorCode, e.g. "NCDP10006".
+	Code string `json:"errorCode,omitempty"`
+
+	// Message is the human-readable summary APIC-EM returned.
+	Message string `json:"message,omitempty"`
+
+	// Detail expands on Message with additional context, when the controller provides it.
+	Detail string `json:"detail,omitempty"`
+
+	// Href is a link to documentation or the offending resource, when present.
+	Href string `json:"href,omitempty"`
+
+	// FieldErrors lists per-field validation failures, when the controller reported any.
+	FieldErrors []FieldError `json:"errors,omitempty"`
+}
+
+func (r *ErrorResponse) Error() string {
+	msg := r.Message
+	if msg == "" {
+		msg = r.Detail
+	}
+
+	// resp.Request is only conventionally set by the stdlib Transport; a caller-supplied
+	// http.RoundTripper (e.g. one wrapping requests for tracing) isn't required to set it.
+	var method, url string
+	if r.HTTPResponse != nil && r.HTTPResponse.Request != nil {
+		method = r.HTTPResponse.Request.Method
+		url = r.HTTPResponse.Request.URL.String()
+	}
+
+	if r.Code != "" {
+		return fmt.Sprintf("%v %v: %d (%s) %v", method, url, r.StatusCode, r.Code, msg)
+	}
+	return fmt.Sprintf("%v %v: %d %v", method, url, r.StatusCode, msg)
+}
+
+// Unwrap classifies r by HTTP status code, so errors.Is(err, ErrNotFound) and similar work
+// against an *ErrorResponse without the caller needing to inspect StatusCode directly.
+func (r *ErrorResponse) Unwrap() error {
+	switch r.StatusCode {
+	case http.StatusNotFound:
+		return ErrNotFound
+	case http.StatusUnauthorized:
+		return ErrUnauthorized
+	case http.StatusConflict:
+		return ErrConflict
+	case http.StatusTooManyRequests:
+		return ErrRateLimited
+	default:
+		return nil
+	}
+}
+
+// Unwrap reports e as an ErrTaskFailed, so errors.Is(err, ErrTaskFailed) works against a
+// *TaskError without a type assertion.
+func (e *TaskError) Unwrap() error { return ErrTaskFailed }
+
+// CheckResponse checks the API response for errors, and returns them if present. A response
+// is considered an error if it has a status code outside the 200 range. API error responses
+// are expected to have either no response body, or a JSON response body that maps to
+// ErrorResponse. Any other response body will be silently ignored. For an error response, the
+// body is fully read before CheckResponse returns, so the connection can be reused; a 2xx
+// response's body is left unread for the caller to decode.
+func CheckResponse(r *http.Response) error {
+	if c := r.StatusCode; c >= 200 && c <= 299 {
+		return nil
+	}
+
+	data, readErr := ioutil.ReadAll(r.Body)
+
+	errorResponse := &ErrorResponse{HTTPResponse: r, StatusCode: r.StatusCode}
+	if readErr == nil && len(data) > 0 {
+		json.Unmarshal(data, errorResponse)
+	}
+	return errorResponse
+}
@@ -0,0 +1,96 @@
+package apicem
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestCheckResponseOK(t *testing.T) {
+	resp := &http.Response{StatusCode: http.StatusOK}
+	if err := CheckResponse(resp); err != nil {
+		t.Fatalf("CheckResponse(200) = %v, want nil", err)
+	}
+}
+
+func TestCheckResponseDecodesErrorBody(t *testing.T) {
+	body := `{"errorCode":"NCDP10006","message":"bad request","detail":"the x field is required"}`
+	resp := httptest.NewRecorder()
+	resp.WriteHeader(http.StatusBadRequest)
+	resp.Body.WriteString(body)
+	httpResp := resp.Result()
+	httpResp.Request = httptest.NewRequest(http.MethodPost, "http://example.com/x", nil)
+
+	err := CheckResponse(httpResp)
+	if err == nil {
+		t.Fatal("CheckResponse(400) = nil, want an error")
+	}
+
+	var errResp *ErrorResponse
+	if !errors.As(err, &errResp) {
+		t.Fatalf("CheckResponse error is %T, want *ErrorResponse", err)
+	}
+	if errResp.Code != "NCDP10006" || errResp.Message != "bad request" || errResp.Detail != "the x field is required" {
+		t.Fatalf("errResp = %+v, want decoded errorCode/message/detail", errResp)
+	}
+	if errResp.StatusCode != http.StatusBadRequest {
+		t.Fatalf("StatusCode = %d, want 400", errResp.StatusCode)
+	}
+	if !strings.Contains(err.Error(), "NCDP10006") {
+		t.Fatalf("Error() = %q, want it to mention the error code", err.Error())
+	}
+}
+
+func TestCheckResponseDrainsBody(t *testing.T) {
+	resp := httptest.NewRecorder()
+	resp.WriteHeader(http.StatusInternalServerError)
+	resp.Body.WriteString(`{"message":"boom"}`)
+	httpResp := resp.Result()
+	httpResp.Request = httptest.NewRequest(http.MethodGet, "http://example.com/x", nil)
+
+	_ = CheckResponse(httpResp)
+
+	if n, _ := httpResp.Body.Read(make([]byte, 1)); n != 0 {
+		t.Fatal("expected the response body to already be fully drained")
+	}
+}
+
+func TestErrorResponseClassification(t *testing.T) {
+	tests := []struct {
+		status int
+		check  func(error) bool
+	}{
+		{http.StatusNotFound, IsNotFound},
+		{http.StatusUnauthorized, IsUnauthorized},
+		{http.StatusConflict, IsConflict},
+		{http.StatusTooManyRequests, IsRateLimited},
+	}
+
+	for _, tt := range tests {
+		err := &ErrorResponse{StatusCode: tt.status}
+		if !tt.check(err) {
+			t.Errorf("status %d: classification predicate returned false", tt.status)
+		}
+	}
+}
+
+func TestErrorResponseErrorNoPanicOnNilRequest(t *testing.T) {
+	err := &ErrorResponse{
+		HTTPResponse: &http.Response{StatusCode: http.StatusInternalServerError},
+		StatusCode:   http.StatusInternalServerError,
+		Message:      "boom",
+	}
+
+	if got := err.Error(); !strings.Contains(got, "boom") {
+		t.Fatalf("Error() = %q, want it to mention the message", got)
+	}
+}
+
+func TestIsTaskFailed(t *testing.T) {
+	err := &TaskError{TaskID: "t-1", ErrorCode: "E1", FailureReason: "boom"}
+	if !IsTaskFailed(err) {
+		t.Fatal("IsTaskFailed(*TaskError) = false, want true")
+	}
+}
@@ -0,0 +1,101 @@
+package apicem
+
+import (
+	"context"
+	"log/slog"
+)
+
+// Logger receives structured events emitted by Client.Do: request start, retry, response,
+// and error. Implementations must be safe for concurrent use. The zero value of Client uses
+// a no-op Logger, so instrumentation is entirely opt-in.
+type Logger interface {
+	Debug(ctx context.Context, msg string, keyvals ...interface{})
+	Info(ctx context.Context, msg string, keyvals ...interface{})
+	Warn(ctx context.Context, msg string, keyvals ...interface{})
+	Error(ctx context.Context, msg string, keyvals ...interface{})
+}
+
+// noopLogger discards every event. It is the default Logger on a Client.
+type noopLogger struct{}
+
+func (noopLogger) Debug(context.Context, string, ...interface{}) {}
+func (noopLogger) Info(context.Context, string, ...interface{})  {}
+func (noopLogger) Warn(context.Context, string, ...interface{})  {}
+func (noopLogger) Error(context.Context, string, ...interface{}) {}
+
+// SetLogger is a client option that installs logger to receive the structured events Do
+// emits for every request. Pass a *SlogLogger or the Logger returned by NewKVLogger to plug in
+// log/slog or go-kit/log, or implement Logger directly.
+func SetLogger(logger Logger) ClientOpt {
+	return func(c *Client) error {
+		if logger == nil {
+			logger = noopLogger{}
+		}
+		c.logger = logger
+		return nil
+	}
+}
+
+// SlogLogger adapts a *slog.Logger to the Logger interface.
+type SlogLogger struct {
+	l *slog.Logger
+}
+
+// NewSlogLogger returns a Logger that forwards events to l.
+func NewSlogLogger(l *slog.Logger) *SlogLogger {
+	return &SlogLogger{l: l}
+}
+
+func (s *SlogLogger) Debug(ctx context.Context, msg string, keyvals ...interface{}) {
+	s.l.DebugContext(ctx, msg, keyvals...)
+}
+
+func (s *SlogLogger) Info(ctx context.Context, msg string, keyvals ...interface{}) {
+	s.l.InfoContext(ctx, msg, keyvals...)
+}
+
+func (s *SlogLogger) Warn(ctx context.Context, msg string, keyvals ...interface{}) {
+	s.l.WarnContext(ctx, msg, keyvals...)
+}
+
+func (s *SlogLogger) Error(ctx context.Context, msg string, keyvals ...interface{}) {
+	s.l.ErrorContext(ctx, msg, keyvals...)
+}
+
+// KVLogger is the subset of the go-kit/log Logger interface ("Log(keyvals ...interface{})
+// error") that NewKVLogger adapts, so callers can pass a go-kit logger without this package
+// depending on go-kit directly.
+type KVLogger interface {
+	Log(keyvals ...interface{}) error
+}
+
+// kvLoggerAdapter adapts a KVLogger (such as a go-kit/log.Logger) to the Logger interface,
+// prefixing every entry with a "level" keyval.
+type kvLoggerAdapter struct {
+	l KVLogger
+}
+
+// NewKVLogger returns a Logger that forwards events to l, in the style of go-kit/log.
+func NewKVLogger(l KVLogger) Logger {
+	return &kvLoggerAdapter{l: l}
+}
+
+func (k *kvLoggerAdapter) Debug(_ context.Context, msg string, keyvals ...interface{}) {
+	k.log("debug", msg, keyvals...)
+}
+
+func (k *kvLoggerAdapter) Info(_ context.Context, msg string, keyvals ...interface{}) {
+	k.log("info", msg, keyvals...)
+}
+
+func (k *kvLoggerAdapter) Warn(_ context.Context, msg string, keyvals ...interface{}) {
+	k.log("warn", msg, keyvals...)
+}
+
+func (k *kvLoggerAdapter) Error(_ context.Context, msg string, keyvals ...interface{}) {
+	k.log("error", msg, keyvals...)
+}
+
+func (k *kvLoggerAdapter) log(level, msg string, keyvals ...interface{}) {
+	_ = k.l.Log(append([]interface{}{"level", level, "msg", msg}, keyvals...)...)
+}
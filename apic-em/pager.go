@@ -0,0 +1,72 @@
+package apicem
+
+import "context"
+
+// PageFetcher retrieves one page of results for opt. An empty slice (with a nil error)
+// signals there are no more pages. Service List methods take trailing CallOptions, so their
+// ListPager methods wrap them in a closure of this type before passing it to NewPager.
+type PageFetcher[T any] func(ctx context.Context, opt *ListOptions) ([]T, *Response, error)
+
+// Pager iterates the pages of a List endpoint one page at a time. The zero value is not
+// usable; construct one with NewPager, or via a service's ListPager method.
+type Pager[T any] struct {
+	fetch PageFetcher[T]
+	opt   ListOptions
+	page  []T
+	err   error
+	done  bool
+}
+
+// NewPager returns a Pager that walks fetch's pages, starting from opt (page 1 if opt is nil
+// or its Page is unset).
+func NewPager[T any](fetch PageFetcher[T], opt *ListOptions) *Pager[T] {
+	p := &Pager[T]{fetch: fetch}
+	if opt != nil {
+		p.opt = *opt
+	}
+	if p.opt.Page == 0 {
+		p.opt.Page = 1
+	}
+	return p
+}
+
+// Next fetches the next page of results, returning false once the server returns an empty
+// page or a call errors. Check Err after Next returns false to tell the two apart.
+func (p *Pager[T]) Next(ctx context.Context) bool {
+	if p.done || p.err != nil {
+		return false
+	}
+
+	items, _, err := p.fetch(ctx, &p.opt)
+	if err != nil {
+		p.err = err
+		return false
+	}
+	if len(items) == 0 {
+		p.done = true
+		return false
+	}
+
+	p.page = items
+	p.opt.Page++
+	return true
+}
+
+// Page returns the items fetched by the most recent call to Next.
+func (p *Pager[T]) Page() []T { return p.page }
+
+// Err returns the error, if any, that stopped iteration.
+func (p *Pager[T]) Err() error { return p.err }
+
+// All drains the pager, returning every item across all pages, or the first error
+// encountered.
+func (p *Pager[T]) All(ctx context.Context) ([]T, error) {
+	var all []T
+	for p.Next(ctx) {
+		all = append(all, p.Page()...)
+	}
+	if err := p.Err(); err != nil {
+		return nil, err
+	}
+	return all, nil
+}
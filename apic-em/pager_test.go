@@ -0,0 +1,93 @@
+package apicem
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestPagerAllConcatenatesPagesUntilEmpty(t *testing.T) {
+	pages := [][]int{{1, 2}, {3}, {}}
+	var calls int
+	fetch := func(ctx context.Context, opt *ListOptions) ([]int, *Response, error) {
+		page := pages[calls]
+		calls++
+		return page, nil, nil
+	}
+
+	all, err := NewPager(fetch, nil).All(context.Background())
+	if err != nil {
+		t.Fatalf("All: %v", err)
+	}
+	if got, want := all, []int{1, 2, 3}; !equalInts(got, want) {
+		t.Fatalf("All() = %v, want %v", got, want)
+	}
+	if calls != len(pages) {
+		t.Fatalf("fetch called %d times, want %d (stop at the first empty page)", calls, len(pages))
+	}
+}
+
+func TestPagerNextStopsAndSticksOnError(t *testing.T) {
+	wantErr := errors.New("boom")
+	var calls int
+	fetch := func(ctx context.Context, opt *ListOptions) ([]int, *Response, error) {
+		calls++
+		if calls == 1 {
+			return []int{1}, nil, nil
+		}
+		return nil, nil, wantErr
+	}
+
+	p := NewPager(fetch, nil)
+
+	if !p.Next(context.Background()) {
+		t.Fatal("Next() = false on the first page, want true")
+	}
+	if p.Next(context.Background()) {
+		t.Fatal("Next() = true on the erroring page, want false")
+	}
+	if !errors.Is(p.Err(), wantErr) {
+		t.Fatalf("Err() = %v, want %v", p.Err(), wantErr)
+	}
+
+	if p.Next(context.Background()) {
+		t.Fatal("Next() after an error should keep returning false, not resume fetching")
+	}
+	if calls != 2 {
+		t.Fatalf("fetch called %d times, want exactly 2 (no further calls after the error)", calls)
+	}
+}
+
+func TestPagerPageReflectsOnlyTheLatestPage(t *testing.T) {
+	pages := [][]int{{1, 2}, {3, 4}, {}}
+	var calls int
+	fetch := func(ctx context.Context, opt *ListOptions) ([]int, *Response, error) {
+		page := pages[calls]
+		calls++
+		return page, nil, nil
+	}
+
+	p := NewPager(fetch, nil)
+
+	p.Next(context.Background())
+	if got, want := p.Page(), []int{1, 2}; !equalInts(got, want) {
+		t.Fatalf("Page() after first Next() = %v, want %v", got, want)
+	}
+
+	p.Next(context.Background())
+	if got, want := p.Page(), []int{3, 4}; !equalInts(got, want) {
+		t.Fatalf("Page() after second Next() = %v, want %v", got, want)
+	}
+}
+
+func equalInts(a, b []int) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
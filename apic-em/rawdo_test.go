@@ -0,0 +1,53 @@
+package apicem
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+var errBodyClose = errors.New("body close failed")
+
+// closeErrBody wraps a Reader so Close reports errBodyClose, letting the test observe
+// whether rawDo's deferred Close error actually reaches the caller.
+type closeErrBody struct {
+	io.Reader
+}
+
+func (b *closeErrBody) Close() error { return errBodyClose }
+
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) { return f(req) }
+
+func TestRawDoSurfacesBodyCloseError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(srv.Close)
+
+	c := NewClient(&http.Client{
+		Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+			resp, err := http.DefaultTransport.RoundTrip(req)
+			if err != nil {
+				return nil, err
+			}
+			resp.Body = &closeErrBody{Reader: resp.Body}
+			return resp, nil
+		}),
+	})
+	if err := SetBaseURL(srv.URL + "/")(c); err != nil {
+		t.Fatalf("SetBaseURL: %v", err)
+	}
+
+	req, err := c.NewRequest(http.MethodGet, "x", nil)
+	if err != nil {
+		t.Fatalf("NewRequest: %v", err)
+	}
+
+	if _, err := c.rawDo(req, nil); !errors.Is(err, errBodyClose) {
+		t.Fatalf("rawDo error = %v, want the body's Close error to surface", err)
+	}
+}
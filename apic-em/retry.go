@@ -0,0 +1,245 @@
+package apicem
+
+import (
+	"errors"
+	"math/rand"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Rate reports the rate limit status the controller returned with a response, parsed from
+// the X-RateLimit-* headers. A zero Rate means the response carried no rate limit headers.
+type Rate struct {
+	Limit     int
+	Remaining int
+	Reset     time.Time
+}
+
+// RetryPolicy controls how Client.Do retries a request that failed with a retryable error.
+type RetryPolicy struct {
+	// MaxRetries is the maximum number of retry attempts after the initial try.
+	MaxRetries int
+
+	// MinBackoff is the delay before the first retry. Each subsequent retry doubles it, up
+	// to MaxBackoff, unless the response carries a Retry-After or X-RateLimit-Reset header.
+	MinBackoff time.Duration
+
+	// MaxBackoff caps the computed delay between retries.
+	MaxBackoff time.Duration
+
+	// Jitter is the fraction (0-1) of additional random delay added on top of the computed
+	// backoff, to avoid many clients retrying in lockstep.
+	Jitter float64
+
+	// RetryableFunc decides whether a failed attempt should be retried. req is the request
+	// that was attempted, resp is nil if err is a transport-level error (no response was
+	// received at all). The default, DefaultRetryableFunc, is used when nil.
+	RetryableFunc func(req *http.Request, resp *http.Response, err error) bool
+}
+
+// DefaultRetryPolicy returns the RetryPolicy used by NewClient: up to 3 retries of
+// idempotent requests on 5xx and 429 responses or connection errors, backing off from
+// 500ms up to 30s with 20% jitter.
+func DefaultRetryPolicy() *RetryPolicy {
+	return &RetryPolicy{
+		MaxRetries:    3,
+		MinBackoff:    500 * time.Millisecond,
+		MaxBackoff:    30 * time.Second,
+		Jitter:        0.2,
+		RetryableFunc: DefaultRetryableFunc,
+	}
+}
+
+// noRetryPolicy is used when a Client's RetryPolicy is explicitly set to nil: it always
+// sends exactly one attempt.
+var noRetryPolicy = &RetryPolicy{RetryableFunc: func(*http.Request, *http.Response, error) bool { return false }}
+
+// DefaultRetryableFunc retries, for idempotent methods only, 429/5xx responses and
+// connection-level errors (when no response was received at all).
+//
+// The status-code check runs regardless of err: CheckResponse always returns a non-nil
+// *ErrorResponse alongside resp for any non-2xx status, so gating on err == nil here would
+// mean 429s and 5xx responses - the very thing this policy exists to retry - are never
+// reached. Idempotency is decided from req.Method rather than resp.Request.Method, since
+// resp.Request is only conventionally set by the stdlib Transport - a caller-supplied
+// http.RoundTripper (as chunk0-5's tracing hook encourages) is not required to set it.
+func DefaultRetryableFunc(req *http.Request, resp *http.Response, err error) bool {
+	if req == nil || !isIdempotentMethod(req.Method) {
+		return false
+	}
+
+	if resp != nil {
+		return resp.StatusCode == http.StatusTooManyRequests || (resp.StatusCode >= 500 && resp.StatusCode <= 599)
+	}
+
+	// No response at all: a genuine transport-level failure.
+	if err == nil {
+		return false
+	}
+	var netErr net.Error
+	if errors.As(err, &netErr) {
+		return true
+	}
+	return isConnResetErr(err)
+}
+
+func isIdempotentMethod(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	default:
+		return false
+	}
+}
+
+func isConnResetErr(err error) bool {
+	msg := err.Error()
+	return strings.Contains(msg, "connection reset") || strings.Contains(msg, "broken pipe") ||
+		strings.Contains(msg, "EOF")
+}
+
+func (p *RetryPolicy) retryable(req *http.Request, resp *http.Response, err error) bool {
+	if p.RetryableFunc != nil {
+		return p.RetryableFunc(req, resp, err)
+	}
+	return DefaultRetryableFunc(req, resp, err)
+}
+
+// backoff computes the delay before the next attempt, honoring a Retry-After or
+// X-RateLimit-Reset header on resp when present.
+func (p *RetryPolicy) backoff(attempt int, resp *http.Response) time.Duration {
+	if resp != nil {
+		if d, ok := retryAfter(resp.Header); ok {
+			return d
+		}
+	}
+
+	d := p.MinBackoff << uint(attempt)
+	if d <= 0 || d > p.MaxBackoff {
+		d = p.MaxBackoff
+	}
+	if p.Jitter > 0 {
+		d += time.Duration(rand.Float64() * p.Jitter * float64(d))
+	}
+	return d
+}
+
+// retryAfter parses the Retry-After header (either delay-seconds or an HTTP date), falling
+// back to X-RateLimit-Reset (unix seconds) if present.
+func retryAfter(header http.Header) (time.Duration, bool) {
+	if v := header.Get("Retry-After"); v != "" {
+		if secs, err := strconv.Atoi(v); err == nil {
+			return time.Duration(secs) * time.Second, true
+		}
+		if t, err := http.ParseTime(v); err == nil {
+			return time.Until(t), true
+		}
+	}
+	if v := header.Get("X-RateLimit-Reset"); v != "" {
+		if secs, err := strconv.ParseInt(v, 10, 64); err == nil {
+			return time.Until(time.Unix(secs, 0)), true
+		}
+	}
+	return 0, false
+}
+
+// parseRate extracts rate limit information from the X-RateLimit-* headers, if present.
+func parseRate(header http.Header) Rate {
+	var rate Rate
+	if v := header.Get("X-RateLimit-Limit"); v != "" {
+		rate.Limit, _ = strconv.Atoi(v)
+	}
+	if v := header.Get("X-RateLimit-Remaining"); v != "" {
+		rate.Remaining, _ = strconv.Atoi(v)
+	}
+	if v := header.Get("X-RateLimit-Reset"); v != "" {
+		if secs, err := strconv.ParseInt(v, 10, 64); err == nil {
+			rate.Reset = time.Unix(secs, 0)
+		}
+	}
+	return rate
+}
+
+// Do sends an API request and returns the API response, retrying it according to
+// c.RetryPolicy (NewClient sets DefaultRetryPolicy; a nil RetryPolicy sends a single attempt,
+// with no retries). The API response is JSON
+// decoded and stored in the value pointed to by v, or returned as an error if an API error
+// has occurred. If v implements the io.Writer interface, the raw response will be written
+// to v, without attempting to decode it.
+//
+// Do respects req's context: retries stop, and Do returns ctx.Err(), as soon as it is
+// cancelled or its deadline is exceeded. Use DoWithContext to attach a context, or per-call
+// options, to a request built by NewRequest.
+func (c *Client) Do(req *http.Request, v interface{}) (*Response, error) {
+	policy := c.RetryPolicy
+	if policy == nil {
+		policy = noRetryPolicy
+	}
+	logger := c.logger
+	if logger == nil {
+		logger = noopLogger{}
+	}
+
+	ctx, endSpan := c.startRequestSpan(req)
+	req = req.WithContext(ctx)
+
+	start := time.Now()
+	logger.Debug(ctx, "apicem: request", "method", req.Method, "url", req.URL.String())
+
+	var resp *Response
+	var err error
+attempts:
+	for attempt := 0; ; attempt++ {
+		resp, err = c.authenticatedDo(req, v)
+
+		var httpResp *http.Response
+		switch {
+		case resp != nil:
+			httpResp = resp.Response
+			resp.Rate = parseRate(httpResp.Header)
+		case err != nil:
+			if errResp, ok := err.(*ErrorResponse); ok {
+				httpResp = errResp.HTTPResponse
+			}
+		}
+
+		if attempt >= policy.MaxRetries || !policy.retryable(req, httpResp, err) {
+			break
+		}
+
+		wait := policy.backoff(attempt, httpResp)
+		logger.Warn(ctx, "apicem: retrying request", "method", req.Method, "url", req.URL.String(),
+			"attempt", attempt+1, "wait", wait, "err", err)
+
+		select {
+		case <-req.Context().Done():
+			err = req.Context().Err()
+			break attempts
+		case <-time.After(wait):
+		}
+
+		if req.GetBody == nil {
+			break
+		}
+		body, berr := req.GetBody()
+		if berr != nil {
+			break
+		}
+		req.Body = body
+	}
+
+	elapsed := time.Since(start)
+	if err != nil {
+		logger.Error(ctx, "apicem: request failed", "method", req.Method, "url", req.URL.String(),
+			"elapsed", elapsed, "err", err)
+	} else {
+		logger.Info(ctx, "apicem: request complete", "method", req.Method, "url", req.URL.String(),
+			"status", resp.StatusCode, "elapsed", elapsed)
+	}
+	endSpan(resp, v, err)
+
+	return resp, err
+}
@@ -0,0 +1,126 @@
+package apicem
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func newTestClient(t *testing.T, handler http.HandlerFunc) *Client {
+	t.Helper()
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+
+	c := NewClient(nil)
+	if err := SetBaseURL(srv.URL + "/")(c); err != nil {
+		t.Fatalf("SetBaseURL: %v", err)
+	}
+	c.RetryPolicy = &RetryPolicy{
+		MaxRetries: 3,
+		MinBackoff: time.Millisecond,
+		MaxBackoff: 5 * time.Millisecond,
+	}
+	return c
+}
+
+func TestDoRetriesIdempotent5xx(t *testing.T) {
+	var hits int
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		hits++
+		w.WriteHeader(http.StatusServiceUnavailable)
+	})
+
+	req, err := c.NewRequest(http.MethodGet, "x", nil)
+	if err != nil {
+		t.Fatalf("NewRequest: %v", err)
+	}
+	if _, err := c.Do(req, nil); err == nil {
+		t.Fatal("expected an error from a server that always returns 503")
+	}
+	if want := 1 + c.RetryPolicy.MaxRetries; hits != want {
+		t.Fatalf("hits = %d, want %d (initial attempt + retries)", hits, want)
+	}
+}
+
+func TestDoRetriesIdempotent429(t *testing.T) {
+	var hits int
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		hits++
+		w.WriteHeader(http.StatusTooManyRequests)
+	})
+
+	req, err := c.NewRequest(http.MethodGet, "x", nil)
+	if err != nil {
+		t.Fatalf("NewRequest: %v", err)
+	}
+	if _, err := c.Do(req, nil); err == nil {
+		t.Fatal("expected an error from a server that always returns 429")
+	}
+	if want := 1 + c.RetryPolicy.MaxRetries; hits != want {
+		t.Fatalf("hits = %d, want %d (initial attempt + retries)", hits, want)
+	}
+}
+
+func TestDoDoesNotRetryNonIdempotent5xx(t *testing.T) {
+	var hits int
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		hits++
+		w.WriteHeader(http.StatusServiceUnavailable)
+	})
+
+	req, err := c.NewRequest(http.MethodPost, "x", nil)
+	if err != nil {
+		t.Fatalf("NewRequest: %v", err)
+	}
+	if _, err := c.Do(req, nil); err == nil {
+		t.Fatal("expected an error")
+	}
+	if hits != 1 {
+		t.Fatalf("hits = %d, want 1: a POST must not be retried on 5xx", hits)
+	}
+}
+
+// TestDefaultRetryableFuncIgnoresTransportBranchForNonIdempotentMethod guards against the
+// converse bug: a bare transport-shaped error (here, one that happens to contain "EOF",
+// which isConnResetErr matches on) must not be retried for a non-idempotent method, since the
+// caller has no response and so cannot know whether the request was already applied.
+func TestDefaultRetryableFuncIgnoresTransportBranchForNonIdempotentMethod(t *testing.T) {
+	req := httptest.NewRequest(http.MethodPost, "http://example.com/x", nil)
+	if DefaultRetryableFunc(req, nil, errors.New("unexpected EOF")) {
+		t.Fatal("DefaultRetryableFunc must not retry a POST with no response, even on an EOF-shaped error")
+	}
+
+	req = httptest.NewRequest(http.MethodGet, "http://example.com/x", nil)
+	if !DefaultRetryableFunc(req, nil, errors.New("unexpected EOF")) {
+		t.Fatal("DefaultRetryableFunc should retry a GET with no response on an EOF-shaped error")
+	}
+}
+
+func TestDefaultRetryableFuncNoPanicOnNilResponseRequest(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "http://example.com/x", nil)
+	resp := &http.Response{StatusCode: http.StatusServiceUnavailable}
+
+	if !DefaultRetryableFunc(req, resp, &ErrorResponse{HTTPResponse: resp, StatusCode: resp.StatusCode}) {
+		t.Fatal("expected a 503 GET to be retryable even when resp.Request is nil")
+	}
+}
+
+func TestDoStopsOnContextCancellation(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	req, err := c.NewRequestWithContext(ctx, http.MethodGet, "x", nil)
+	if err != nil {
+		t.Fatalf("NewRequestWithContext: %v", err)
+	}
+	if _, err := c.Do(req, nil); err == nil {
+		t.Fatal("expected an error once the context is cancelled")
+	}
+}
@@ -0,0 +1,178 @@
+package apicem
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// TaskService handles communication with the task related methods of the APIC-EM API,
+// used to poll the outcome of the asynchronous operations most mutating calls kick off.
+type TaskService service
+
+// Task is the status of an asynchronous APIC-EM operation, returned by the /task endpoint.
+type Task struct {
+	ID            string `json:"id"`
+	OperationID   string `json:"operationIdList"`
+	Progress      string `json:"progress"`
+	StartTime     int64  `json:"startTime"`
+	EndTime       int64  `json:"endTime"`
+	IsError       bool   `json:"isError"`
+	ErrorCode     string `json:"errorCode"`
+	FailureReason string `json:"failureReason"`
+	Data          string `json:"data"`
+}
+
+// taskResponse is the envelope the /task/{id} endpoint wraps a Task in.
+type taskResponse struct {
+	Response Task `json:"response"`
+}
+
+// Get retrieves the current status of the task identified by taskID.
+func (s *TaskService) Get(ctx context.Context, taskID string, opts ...CallOption) (*Task, *Response, error) {
+	req, err := s.client.NewRequestWithContext(ctx, "GET", "task/"+taskID, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var tr taskResponse
+	resp, err := s.client.DoWithContext(ctx, req, &tr, opts...)
+	if err != nil {
+		return nil, resp, err
+	}
+	return &tr.Response, resp, nil
+}
+
+// taskListResponse is the envelope the /task endpoint wraps a page of Task in.
+type taskListResponse struct {
+	Response []Task `json:"response"`
+}
+
+// List retrieves one page of tasks, per opt.
+func (s *TaskService) List(ctx context.Context, opt *ListOptions, opts ...CallOption) ([]Task, *Response, error) {
+	u, err := addOptions("task", opt)
+	if err != nil {
+		return nil, nil, err
+	}
+	req, err := s.client.NewRequestWithContext(ctx, "GET", u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var tr taskListResponse
+	resp, err := s.client.DoWithContext(ctx, req, &tr, opts...)
+	if err != nil {
+		return nil, resp, err
+	}
+	return tr.Response, resp, nil
+}
+
+// ListPager returns a Pager that walks every page of tasks, starting from opt, applying opts
+// to every page request.
+func (s *TaskService) ListPager(opt *ListOptions, opts ...CallOption) *Pager[Task] {
+	return NewPager(func(ctx context.Context, opt *ListOptions) ([]Task, *Response, error) {
+		return s.List(ctx, opt, opts...)
+	}, opt)
+}
+
+// ListAll returns every task across all pages.
+func (s *TaskService) ListAll(ctx context.Context, opt *ListOptions, opts ...CallOption) ([]Task, error) {
+	return s.ListPager(opt, opts...).All(ctx)
+}
+
+// done reports whether t has reached a terminal state.
+func (t *Task) done() bool {
+	return t.IsError || t.EndTime > 0
+}
+
+// TaskError reports that an asynchronous APIC-EM task finished in an error state.
+type TaskError struct {
+	TaskID        string
+	ErrorCode     string
+	FailureReason string
+}
+
+func (e *TaskError) Error() string {
+	return fmt.Sprintf("apicem: task %s failed: %s (code %s)", e.TaskID, e.FailureReason, e.ErrorCode)
+}
+
+// TaskPollPolicy controls how WaitForTask polls the controller for a task's completion.
+type TaskPollPolicy struct {
+	// Interval is the delay between the first two polls. Each subsequent poll doubles it,
+	// up to MaxInterval.
+	Interval time.Duration
+
+	// MaxInterval caps the computed delay between polls.
+	MaxInterval time.Duration
+
+	// Timeout bounds the total time WaitForTask will wait, on top of any deadline already
+	// carried by the context. Zero means no additional timeout.
+	Timeout time.Duration
+}
+
+// DefaultTaskPollPolicy returns the TaskPollPolicy used by WaitForTask when none is given:
+// poll every second, backing off up to 10s, and give up after 5 minutes.
+func DefaultTaskPollPolicy() *TaskPollPolicy {
+	return &TaskPollPolicy{
+		Interval:    1 * time.Second,
+		MaxInterval: 10 * time.Second,
+		Timeout:     5 * time.Minute,
+	}
+}
+
+// TaskOption customizes a single WaitForTask call, without affecting any other in-flight call.
+type TaskOption func(*TaskPollPolicy)
+
+// WithPollInterval overrides the initial delay between polls.
+func WithPollInterval(d time.Duration) TaskOption {
+	return func(p *TaskPollPolicy) { p.Interval = d }
+}
+
+// WithPollTimeout overrides how long WaitForTask will wait before giving up.
+func WithPollTimeout(d time.Duration) TaskOption {
+	return func(p *TaskPollPolicy) { p.Timeout = d }
+}
+
+// WaitForTask polls taskID via Task.Get, backing off exponentially between attempts, until
+// the task completes, fails, ctx is cancelled, or the poll policy's Timeout elapses. On
+// success it returns the final Task. If the task itself finished in an error state, it
+// returns the decoded Task alongside a *TaskError.
+func (c *Client) WaitForTask(ctx context.Context, taskID string, opts ...TaskOption) (*Task, error) {
+	policy := DefaultTaskPollPolicy()
+	for _, opt := range opts {
+		opt(policy)
+	}
+
+	if policy.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, policy.Timeout)
+		defer cancel()
+	}
+
+	wait := policy.Interval
+	for {
+		task, _, err := c.Task.Get(ctx, taskID)
+		if err != nil {
+			return nil, err
+		}
+		if task.done() {
+			if task.IsError {
+				return task, &TaskError{TaskID: taskID, ErrorCode: task.ErrorCode, FailureReason: task.FailureReason}
+			}
+			return task, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(wait):
+		}
+
+		if wait < policy.MaxInterval {
+			wait *= 2
+			if wait > policy.MaxInterval {
+				wait = policy.MaxInterval
+			}
+		}
+	}
+}
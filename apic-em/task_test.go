@@ -0,0 +1,104 @@
+package apicem
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func newTaskTestClient(t *testing.T, handler http.HandlerFunc) *Client {
+	t.Helper()
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+
+	c := NewClient(nil)
+	if err := SetBaseURL(srv.URL + "/")(c); err != nil {
+		t.Fatalf("SetBaseURL: %v", err)
+	}
+	c.RetryPolicy = &RetryPolicy{} // no HTTP-level retries; WaitForTask has its own loop
+	return c
+}
+
+func writeTask(w http.ResponseWriter, task Task) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(taskResponse{Response: task})
+}
+
+func TestWaitForTaskPollsUntilDone(t *testing.T) {
+	var hits int
+	c := newTaskTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		hits++
+		if hits < 3 {
+			writeTask(w, Task{ID: "t1"})
+			return
+		}
+		writeTask(w, Task{ID: "t1", EndTime: 1, Data: `{"id":42}`})
+	})
+
+	task, err := c.WaitForTask(context.Background(), "t1", WithPollInterval(time.Millisecond))
+	if err != nil {
+		t.Fatalf("WaitForTask: %v", err)
+	}
+	if task.Data != `{"id":42}` {
+		t.Fatalf("task.Data = %q, want the final task's data", task.Data)
+	}
+	if hits < 3 {
+		t.Fatalf("hits = %d, want at least 3 polls before completion", hits)
+	}
+}
+
+func TestWaitForTaskReturnsTaskErrorOnFailure(t *testing.T) {
+	c := newTaskTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		writeTask(w, Task{ID: "t1", IsError: true, ErrorCode: "E1", FailureReason: "boom"})
+	})
+
+	_, err := c.WaitForTask(context.Background(), "t1", WithPollInterval(time.Millisecond))
+	if err == nil {
+		t.Fatal("expected an error for a task that finished with IsError=true")
+	}
+
+	var taskErr *TaskError
+	if !errors.As(err, &taskErr) {
+		t.Fatalf("err = %v (%T), want a *TaskError", err, err)
+	}
+	if taskErr.ErrorCode != "E1" || taskErr.FailureReason != "boom" {
+		t.Fatalf("taskErr = %+v, want ErrorCode=E1 FailureReason=boom", taskErr)
+	}
+	if !IsTaskFailed(err) {
+		t.Fatal("IsTaskFailed(err) = false, want true")
+	}
+}
+
+func TestWaitForTaskRespectsPollTimeout(t *testing.T) {
+	c := newTaskTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		writeTask(w, Task{ID: "t1"}) // never completes
+	})
+
+	start := time.Now()
+	_, err := c.WaitForTask(context.Background(), "t1",
+		WithPollInterval(5*time.Millisecond), WithPollTimeout(20*time.Millisecond))
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("err = %v, want context.DeadlineExceeded", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Fatalf("WaitForTask took %v, want it to abort close to the poll timeout", elapsed)
+	}
+}
+
+func TestWaitForTaskRespectsContextCancellation(t *testing.T) {
+	c := newTaskTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		writeTask(w, Task{ID: "t1"}) // never completes
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	_, err := c.WaitForTask(ctx, "t1", WithPollInterval(5*time.Millisecond))
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("err = %v, want context.DeadlineExceeded", err)
+	}
+}
@@ -0,0 +1,46 @@
+package apicem
+
+import "context"
+
+// TicketService handles communication with the authentication ticket related methods of
+// the APIC-EM API. See BasicAuthAuthenticator for the Authenticator built on top of it.
+type TicketService service
+
+// Ticket is the service ticket APIC-EM issues in exchange for a username and password.
+type Ticket struct {
+	ServiceTicket  string `json:"serviceTicket"`
+	IdleTimeout    int    `json:"idleTimeout"`
+	SessionTimeout int    `json:"sessionTimeout"`
+}
+
+// ticketRequest is the body posted to the APIC-EM /ticket endpoint.
+type ticketRequest struct {
+	Username string `json:"username"`
+	Password string `json:"password"`
+}
+
+// ticketResponse is the envelope the /ticket endpoint wraps a Ticket in.
+type ticketResponse struct {
+	Response Ticket `json:"response"`
+}
+
+// Create exchanges username and password for a new service ticket.
+func (s *TicketService) Create(ctx context.Context, username, password string) (*Ticket, *Response, error) {
+	req, err := s.client.NewRequestWithContext(ctx, "POST", "ticket", &ticketRequest{
+		Username: username,
+		Password: password,
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var tr ticketResponse
+	// Bypass Client.Do: callers that use this to implement an Authenticator (such as
+	// BasicAuthAuthenticator) would otherwise try to authenticate this very request with
+	// the token it is meant to obtain.
+	resp, err := s.client.rawDo(req, &tr)
+	if err != nil {
+		return nil, resp, err
+	}
+	return &tr.Response, resp, nil
+}
@@ -0,0 +1,63 @@
+package apicem
+
+import (
+	"context"
+	"net/http"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// WithTracer is a client option that opens a span (named "apicem.Do") around every request
+// Client.Do makes, recording the HTTP method, URL and status code, and the APIC-EM task id
+// when the decoded response carries one. The span's context is propagated to req, so nested
+// spans created further down the call stack (e.g. inside an http.RoundTripper) parent
+// correctly.
+func WithTracer(tracer trace.Tracer) ClientOpt {
+	return func(c *Client) error {
+		c.tracer = tracer
+		return nil
+	}
+}
+
+// taskIDCarrier is implemented by response envelopes that wrap an APIC-EM taskId, so
+// startRequestSpan can record it as a span attribute without knowing the concrete type of v.
+type taskIDCarrier interface {
+	taskID() string
+}
+
+func (t *taskIDResponse) taskID() string { return t.Response.TaskID }
+
+// startRequestSpan opens a span for req if c.tracer is set, returning a no-op function
+// otherwise. The returned func ends the span, recording resp's status code (or err) first.
+func (c *Client) startRequestSpan(req *http.Request) (context.Context, func(resp *Response, v interface{}, err error)) {
+	if c.tracer == nil {
+		return req.Context(), func(*Response, interface{}, error) {}
+	}
+
+	ctx, span := c.tracer.Start(req.Context(), "apicem.Do",
+		trace.WithAttributes(
+			attribute.String("http.method", req.Method),
+			attribute.String("http.url", req.URL.String()),
+		),
+	)
+
+	return ctx, func(resp *Response, v interface{}, err error) {
+		defer span.End()
+
+		if carrier, ok := v.(taskIDCarrier); ok {
+			if id := carrier.taskID(); id != "" {
+				span.SetAttributes(attribute.String("apicem.task_id", id))
+			}
+		}
+
+		if resp != nil {
+			span.SetAttributes(attribute.Int("http.status_code", resp.StatusCode))
+		}
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+	}
+}